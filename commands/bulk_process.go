@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+
+	"receipt-processor/models"
+)
+
+// defaultMaxBulkSize is the default cap on how many receipts a single
+// /receipts/process/bulk request may contain, configurable via
+// BULK_MAX_SIZE.
+const defaultMaxBulkSize = 100
+
+// maxBulkBodyBytes bounds how large a /receipts/process/bulk request body
+// may be, so an oversized body is rejected while streaming instead of
+// being fully buffered before the batch-size check can run.
+const maxBulkBodyBytes = 10 << 20 // 10 MiB
+
+// BulkProcessCommand decodes and caps the body of a
+// /receipts/process/bulk request. Individual receipts are not validated
+// here, since one invalid receipt should not fail the whole batch; that
+// happens per-item in the service layer.
+type BulkProcessCommand struct {
+	Receipts []models.Receipt
+}
+
+// LoadDataFromRequest reads the request body and enforces the maximum
+// batch size.
+func (c *BulkProcessCommand) LoadDataFromRequest(w http.ResponseWriter, r *http.Request) error {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBulkBodyBytes+1))
+	if err != nil {
+		return models.NewInvalidInputError("error reading request body")
+	}
+	if len(body) > maxBulkBodyBytes {
+		return models.NewInvalidInputError("request body too large")
+	}
+
+	var req models.BulkProcessRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return models.NewInvalidInputError("invalid JSON format: " + err.Error())
+	}
+
+	limit := maxBulkSize()
+	if len(req.Receipts) > limit {
+		return models.NewBatchTooLargeError(len(req.Receipts), limit)
+	}
+
+	c.Receipts = req.Receipts
+	return nil
+}
+
+// maxBulkSize returns the configured maximum batch size, falling back to
+// defaultMaxBulkSize.
+func maxBulkSize() int {
+	if v := os.Getenv("BULK_MAX_SIZE"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return n
+		}
+	}
+	return defaultMaxBulkSize
+}
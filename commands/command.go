@@ -0,0 +1,11 @@
+package commands
+
+import "net/http"
+
+// Command decodes and validates a single HTTP request's input. Handlers
+// instantiate a Command, call LoadDataFromRequest, and on error emit the
+// returned *models.ValidationError as a structured JSON response;
+// otherwise they hand the populated Command to the service layer.
+type Command interface {
+	LoadDataFromRequest(w http.ResponseWriter, r *http.Request) error
+}
@@ -0,0 +1,25 @@
+package commands
+
+import (
+	"net/http"
+
+	"receipt-processor/models"
+
+	"github.com/gorilla/mux"
+)
+
+// GetPointsCommand decodes the receipt ID path parameter of a
+// /receipts/{id}/points request.
+type GetPointsCommand struct {
+	ID string
+}
+
+// LoadDataFromRequest reads the {id} path parameter from r.
+func (c *GetPointsCommand) LoadDataFromRequest(w http.ResponseWriter, r *http.Request) error {
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		return models.NewMissingFieldError("id")
+	}
+	c.ID = id
+	return nil
+}
@@ -0,0 +1,144 @@
+// Package commands decodes and validates HTTP request input for the
+// receipt-processor API, keeping that boilerplate out of the handlers.
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"receipt-processor/models"
+)
+
+// Defaults and limits for PagedRequestCommand.
+const (
+	DefaultPage     = 1
+	DefaultPageSize = 25
+	MaxPageSize     = 100
+)
+
+// PagedRequestCommand decodes the paging, sorting, and filtering parameters
+// accepted by the list-receipts endpoint, either from query-string
+// parameters (GET) or a JSON body (POST).
+type PagedRequestCommand struct {
+	Page          int                  `json:"page"`
+	PageSize      int                  `json:"pageSize"`
+	OrderBy       string               `json:"orderBy"`
+	SortDirection string               `json:"sortDirection"`
+	Filter        models.ReceiptFilter `json:"filter"`
+}
+
+// LoadDataFromRequest populates c from r. POST requests with a body are
+// decoded as JSON; everything else is read from query parameters.
+func (c *PagedRequestCommand) LoadDataFromRequest(w http.ResponseWriter, r *http.Request) error {
+	c.Page = DefaultPage
+	c.PageSize = DefaultPageSize
+	c.SortDirection = "asc"
+
+	var err error
+	if r.Method == http.MethodPost && r.ContentLength != 0 {
+		err = json.NewDecoder(r.Body).Decode(c)
+	} else {
+		err = c.loadFromQuery(r.URL.Query())
+	}
+	if err != nil {
+		return models.NewInvalidInputError("invalid request: " + err.Error())
+	}
+
+	return c.validate()
+}
+
+func (c *PagedRequestCommand) loadFromQuery(q url.Values) error {
+	if v := q.Get("page"); v != "" {
+		page, err := strconv.Atoi(v)
+		if err != nil {
+			return models.NewInvalidFormatError("page", v)
+		}
+		c.Page = page
+	}
+	if v := q.Get("pageSize"); v != "" {
+		pageSize, err := strconv.Atoi(v)
+		if err != nil {
+			return models.NewInvalidFormatError("pageSize", v)
+		}
+		c.PageSize = pageSize
+	}
+	if v := q.Get("orderBy"); v != "" {
+		c.OrderBy = v
+	}
+	if v := q.Get("sortDirection"); v != "" {
+		c.SortDirection = v
+	}
+
+	c.Filter.Retailer = q.Get("retailer")
+	c.Filter.PurchaseDateFrom = q.Get("purchaseDateFrom")
+	c.Filter.PurchaseDateTo = q.Get("purchaseDateTo")
+	c.Filter.CreatedAtFrom = q.Get("createdAtFrom")
+	c.Filter.CreatedAtTo = q.Get("createdAtTo")
+
+	var err error
+	if c.Filter.TotalMin, err = parseOptionalFloat(q, "totalMin"); err != nil {
+		return err
+	}
+	if c.Filter.TotalMax, err = parseOptionalFloat(q, "totalMax"); err != nil {
+		return err
+	}
+	if c.Filter.PointsMin, err = parseOptionalInt(q, "pointsMin"); err != nil {
+		return err
+	}
+	if c.Filter.PointsMax, err = parseOptionalInt(q, "pointsMax"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func parseOptionalFloat(q url.Values, key string) (*float64, error) {
+	v := q.Get(key)
+	if v == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return nil, models.NewInvalidFormatError(key, v)
+	}
+	return &parsed, nil
+}
+
+func parseOptionalInt(q url.Values, key string) (*int, error) {
+	v := q.Get(key)
+	if v == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, models.NewInvalidFormatError(key, v)
+	}
+	return &parsed, nil
+}
+
+func (c *PagedRequestCommand) validate() error {
+	if c.Page < 1 {
+		return models.NewInvalidValueError("page", c.Page)
+	}
+	if c.PageSize < 1 || c.PageSize > MaxPageSize {
+		return models.NewInvalidValueError("pageSize", c.PageSize)
+	}
+	if c.SortDirection != "asc" && c.SortDirection != "desc" {
+		return models.NewInvalidValueError("sortDirection", c.SortDirection)
+	}
+	return nil
+}
+
+// Paging returns the models.Paging derived from the decoded command.
+func (c *PagedRequestCommand) Paging() models.Paging {
+	return models.Paging{
+		Page:          c.Page,
+		PageSize:      c.PageSize,
+		OrderBy:       c.OrderBy,
+		SortDirection: c.SortDirection,
+	}
+}
+
+// ListReceiptsCommand is the Command used by GET/POST /receipts.
+type ListReceiptsCommand = PagedRequestCommand
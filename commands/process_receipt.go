@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"receipt-processor/models"
+)
+
+// maxProcessReceiptBodyBytes bounds how large a single /receipts/process
+// request body may be.
+const maxProcessReceiptBodyBytes = 1 << 20 // 1 MiB
+
+// ProcessReceiptCommand decodes and validates the body of a
+// /receipts/process request.
+type ProcessReceiptCommand struct {
+	Receipt models.Receipt
+}
+
+// LoadDataFromRequest reads and validates the request body, populating
+// Receipt on success.
+func (c *ProcessReceiptCommand) LoadDataFromRequest(w http.ResponseWriter, r *http.Request) error {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxProcessReceiptBodyBytes+1))
+	if err != nil {
+		return models.NewInvalidInputError("error reading request body")
+	}
+	if len(body) > maxProcessReceiptBodyBytes {
+		return models.NewInvalidInputError("request body too large")
+	}
+
+	var receipt models.Receipt
+	if err := json.Unmarshal(body, &receipt); err != nil {
+		return models.NewInvalidInputError("invalid JSON format: " + err.Error())
+	}
+
+	if err := ValidateReceipt(receipt); err != nil {
+		return err
+	}
+
+	c.Receipt = receipt
+	return nil
+}
+
+// ValidateReceipt checks that a receipt has all the fields required to
+// calculate its points, in the format the scoring rules expect.
+func ValidateReceipt(receipt models.Receipt) error {
+	if receipt.Retailer == "" {
+		return models.NewMissingFieldError("retailer")
+	}
+	if receipt.PurchaseDate == "" {
+		return models.NewMissingFieldError("purchaseDate")
+	}
+	if receipt.PurchaseTime == "" {
+		return models.NewMissingFieldError("purchaseTime")
+	}
+	if receipt.Total == "" {
+		return models.NewMissingFieldError("total")
+	}
+	if len(receipt.Items) == 0 {
+		return models.NewMissingFieldError("items")
+	}
+
+	if !models.IsValidDateFormat(receipt.PurchaseDate) {
+		return models.NewInvalidFormatError("purchaseDate", receipt.PurchaseDate)
+	}
+	if !models.IsValidTimeFormat(receipt.PurchaseTime) {
+		return models.NewInvalidFormatError("purchaseTime", receipt.PurchaseTime)
+	}
+	if !models.IsValidCurrencyFormat(receipt.Total) {
+		return models.NewInvalidFormatError("total", receipt.Total)
+	}
+
+	for i, item := range receipt.Items {
+		if item.ShortDescription == "" {
+			return models.NewMissingFieldError(itemField(i, "shortDescription"))
+		}
+		if item.Price == "" {
+			return models.NewMissingFieldError(itemField(i, "price"))
+		}
+		if !models.IsValidCurrencyFormat(item.Price) {
+			return models.NewInvalidFormatError(itemField(i, "price"), item.Price)
+		}
+	}
+
+	return nil
+}
+
+func itemField(index int, field string) string {
+	return "items[" + strconv.Itoa(index) + "]." + field
+}
@@ -1,193 +1,154 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
-	"io"
+	"errors"
 	"net/http"
+	"receipt-processor/commands"
 	"receipt-processor/logging"
 	"receipt-processor/models"
-
-	"github.com/gorilla/mux"
+	"receipt-processor/service"
 )
 
 // ReceiptHandler handles receipt-related requests
 type ReceiptHandler struct {
-	Store *models.ReceiptStore
+	Service *service.ReceiptService
 }
 
 // NewReceiptHandler creates a new receipt handler
-func NewReceiptHandler(store *models.ReceiptStore) *ReceiptHandler {
+func NewReceiptHandler(store models.Store) *ReceiptHandler {
 	return &ReceiptHandler{
-		Store: store,
+		Service: service.NewReceiptService(store),
 	}
 }
 
 // ProcessReceipt processes a receipt and returns an ID
 func (h *ReceiptHandler) ProcessReceipt(w http.ResponseWriter, r *http.Request) {
-	// Log the incoming request
-	logging.LogInfo("Processing receipt request", logging.LogParams{
-		"method": r.Method,
-		"path":   r.URL.Path,
-	})
+	log := logging.FromContext(r.Context())
 
-	// Read and parse request body
-	body, err := io.ReadAll(r.Body)
-	if err != nil {
-		logging.LogError("Failed to read request body", logging.LogParams{
-			"error": err.Error(),
-		})
-		http.Error(w, "Error reading request body. Please verify input.", http.StatusBadRequest)
+	var cmd commands.ProcessReceiptCommand
+	if err := cmd.LoadDataFromRequest(w, r); err != nil {
+		log.LogWarn("Invalid process receipt request", logging.LogParams{"error": err.Error()})
+		writeCommandError(w, err)
 		return
 	}
-	defer r.Body.Close()
 
-	// Log the request body for debugging
-	logging.LogInfo("Receipt data received", logging.LogParams{
-		"data": string(body),
-	})
-
-	// Parse JSON
-	var receipt models.Receipt
-	err = json.Unmarshal(body, &receipt)
+	response, err := h.Service.ProcessReceipt(r.Context(), &cmd)
 	if err != nil {
-		logging.LogError("Failed to parse JSON", logging.LogParams{
-			"error": err.Error(),
-		})
-		http.Error(w, "Invalid JSON format. Please verify input.", http.StatusBadRequest)
+		writeServiceError(r.Context(), w, err, "Failed to store receipt")
 		return
 	}
 
-	// Validate receipt
-	if err := validateReceipt(receipt); err != nil {
-		logging.LogWarn("Invalid receipt data", logging.LogParams{
-			"error": string(err.Error()),
-		})
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-
-	// Calculate points for the receipt
-	points := models.CalculatePoints(receipt)
-	receipt.Points = points
-
-	logging.LogInfo("Points calculated", logging.LogParams{
-		"points": points,
-	})
-
-	// Store the receipt with calculated points
-	id := h.Store.AddReceipt(receipt)
+	log.LogInfo("Receipt processed", logging.LogParams{"id": response.ID})
+	writeJSON(w, http.StatusOK, response)
+}
 
-	logging.LogInfo("Receipt processed", logging.LogParams{
-		"id":     id,
-		"points": points,
-	})
+// GetPoints returns the points for a receipt
+func (h *ReceiptHandler) GetPoints(w http.ResponseWriter, r *http.Request) {
+	log := logging.FromContext(r.Context())
 
-	// Prepare and send response
-	response := models.ProcessResponse{
-		ID: id,
+	var cmd commands.GetPointsCommand
+	if err := cmd.LoadDataFromRequest(w, r); err != nil {
+		log.LogWarn("Invalid get points request", logging.LogParams{"error": err.Error()})
+		writeCommandError(w, err)
+		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		logging.LogError("Failed to encode response", logging.LogParams{
-			"error": err.Error(),
-		})
-		http.Error(w, "Failed to generate response", http.StatusInternalServerError)
+	response, found, err := h.Service.GetPoints(r.Context(), &cmd)
+	if err != nil {
+		writeServiceError(r.Context(), w, err, "Failed to look up receipt")
+		return
+	}
+	if !found {
+		log.LogWarn("Receipt not found", logging.LogParams{"id": cmd.ID})
+		http.Error(w, "Receipt not found", http.StatusNotFound)
 		return
 	}
-}
 
-// GetPoints returns the points for a receipt
-func (h *ReceiptHandler) GetPoints(w http.ResponseWriter, r *http.Request) {
-	// Extract ID from URL
-	params := mux.Vars(r)
-	id := params["id"]
+	log.LogInfo("Returning points", logging.LogParams{"id": cmd.ID, "points": response.Points})
+	writeJSON(w, http.StatusOK, response)
+}
 
-	logging.LogInfo("Getting points for receipt", logging.LogParams{
-		"id": id,
-	})
+// ListReceipts returns a paged, filterable list of previously processed receipts
+func (h *ReceiptHandler) ListReceipts(w http.ResponseWriter, r *http.Request) {
+	log := logging.FromContext(r.Context())
 
-	// Get receipt from store
-	receipt, found := h.Store.GetReceipt(id)
-	if !found {
-		logging.LogWarn("Receipt not found", logging.LogParams{
-			"id": id,
-		})
-		http.Error(w, "Receipt not found", http.StatusNotFound)
+	var cmd commands.ListReceiptsCommand
+	if err := cmd.LoadDataFromRequest(w, r); err != nil {
+		log.LogWarn("Invalid list receipts request", logging.LogParams{"error": err.Error()})
+		writeCommandError(w, err)
 		return
 	}
 
-	// Prepare response
-	response := models.PointsResponse{
-		Points: receipt.Points,
+	response, err := h.Service.ListReceipts(r.Context(), &cmd)
+	if err != nil {
+		writeServiceError(r.Context(), w, err, "Failed to list receipts")
+		return
 	}
 
-	logging.LogInfo("Returning points", logging.LogParams{
-		"id":     id,
-		"points": receipt.Points,
+	log.LogInfo("Receipts listed", logging.LogParams{
+		"page":       response.Page,
+		"pageSize":   response.PageSize,
+		"totalCount": response.TotalCount,
 	})
+	writeJSON(w, http.StatusOK, response)
+}
 
-	// Send response
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+// BulkProcessReceipts validates, scores, and stores a batch of receipts
+func (h *ReceiptHandler) BulkProcessReceipts(w http.ResponseWriter, r *http.Request) {
+	log := logging.FromContext(r.Context())
 
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		logging.LogError("Failed to encode response", logging.LogParams{
-			"error": err.Error(),
-		})
-		http.Error(w, "Failed to generate response", http.StatusInternalServerError)
+	var cmd commands.BulkProcessCommand
+	if err := cmd.LoadDataFromRequest(w, r); err != nil {
+		log.LogWarn("Invalid bulk process request", logging.LogParams{"error": err.Error()})
+		writeCommandError(w, err)
 		return
 	}
-}
 
-// validateReceipt validates the receipt data
-func validateReceipt(receipt models.Receipt) error {
-	// Basic validation with proper error types
-	if receipt.Retailer == "" {
-		return models.NewMissingFieldError("retailer")
-	}
-	if receipt.PurchaseDate == "" {
-		return models.NewMissingFieldError("purchaseDate")
-	}
-	if receipt.PurchaseTime == "" {
-		return models.NewMissingFieldError("purchaseTime")
-	}
-	if receipt.Total == "" {
-		return models.NewMissingFieldError("total")
-	}
-	if len(receipt.Items) == 0 {
-		return models.NewMissingFieldError("items")
+	response, err := h.Service.BulkProcessReceipts(r.Context(), &cmd)
+	if err != nil {
+		writeServiceError(r.Context(), w, err, "Failed to store receipts")
+		return
 	}
 
-	// Validate purchase date format (YYYY-MM-DD)
-	if !models.IsValidDateFormat(receipt.PurchaseDate) {
-		return models.NewInvalidFormatError("purchaseDate", receipt.PurchaseDate)
-	}
+	log.LogInfo("Bulk receipts processed", logging.LogParams{"count": len(response.Results)})
+	writeJSON(w, http.StatusOK, response)
+}
 
-	// Validate purchase time format (HH:MM)
-	if !models.IsValidTimeFormat(receipt.PurchaseTime) {
-		return models.NewInvalidFormatError("purchaseTime", receipt.PurchaseTime)
-	}
+// writeJSON encodes body as the JSON response.
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
 
-	// Validate total is a valid number
-	if !models.IsValidCurrencyFormat(receipt.Total) {
-		return models.NewInvalidFormatError("total", receipt.Total)
+// writeCommandError writes a *models.ValidationError returned from a
+// Command as a structured JSON response, using its status code, or falls
+// back to a plain 400 for any other error.
+func writeCommandError(w http.ResponseWriter, err error) {
+	if ve := models.GetValidationError(err); ve != nil {
+		writeJSON(w, ve.StatusCode(), ve)
+		return
 	}
+	http.Error(w, err.Error(), http.StatusBadRequest)
+}
 
-	// Validate each item
-	for i, item := range receipt.Items {
-		if item.ShortDescription == "" {
-			return models.NewMissingFieldError("items[" + string(rune(i)) + "].shortDescription")
-		}
-		if item.Price == "" {
-			return models.NewMissingFieldError("items[" + string(rune(i)) + "].price")
-		}
-		if !models.IsValidCurrencyFormat(item.Price) {
-			return models.NewInvalidFormatError("items["+string(rune(i))+"].price", item.Price)
-		}
+// writeServiceError writes a response for an error returned by the service
+// layer. A request whose deadline elapsed or whose client disconnected is
+// reported as a structured 504/503 JSON error; anything else is logged and
+// reported as a plain 500.
+func writeServiceError(ctx context.Context, w http.ResponseWriter, err error, fallbackMessage string) {
+	log := logging.FromContext(ctx)
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		log.LogWarn(fallbackMessage+": deadline exceeded", logging.LogParams{"error": err.Error()})
+		writeJSON(w, http.StatusGatewayTimeout, models.NewTimeoutError())
+	case errors.Is(err, context.Canceled):
+		log.LogWarn(fallbackMessage+": request canceled", logging.LogParams{"error": err.Error()})
+		writeJSON(w, http.StatusServiceUnavailable, models.NewUnavailableError())
+	default:
+		log.LogError(fallbackMessage, logging.LogParams{"error": err.Error()})
+		http.Error(w, fallbackMessage, http.StatusInternalServerError)
 	}
-
-	return nil
 }
@@ -1,105 +1,135 @@
+// Package logging provides structured, leveled JSON logging with
+// per-request correlation IDs, so log lines can be parsed and correlated
+// by log aggregators instead of grepped as free-form text.
 package logging
 
 import (
-	"fmt"
-	"log"
+	"context"
 	"os"
 	"path/filepath"
-	"runtime"
-	"time"
-)
+	"strings"
 
-var (
-	infoLogger  *log.Logger
-	warnLogger  *log.Logger
-	errorLogger *log.Logger
-	logFile     *os.File
+	"github.com/rs/zerolog"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-// LogParams is a map for structured logging
+// LogParams is a map of arbitrary structured fields attached to a log line.
 type LogParams map[string]interface{}
 
-// InitializeLogger sets up the logger with the specified log directory
+type contextKey int
+
+const requestIDKey contextKey = iota
+
+var logger zerolog.Logger
+
+// InitializeLogger sets up the structured logger, writing one JSON object
+// per line to a rotated file under logDir. The level is configurable via
+// LOG_LEVEL (debug, info, warn, error; defaults to info).
 func InitializeLogger(logDir string) {
-	// Create log directory if it doesn't exist
+	zerolog.TimestampFieldName = "ts"
+	zerolog.MessageFieldName = "msg"
+
 	if err := os.MkdirAll(logDir, 0755); err != nil {
-		log.Fatalf("Failed to create log directory: %v", err)
+		panic("logging: failed to create log directory: " + err.Error())
 	}
 
-	// Create a log file with the current date
-	currentTime := time.Now().Format("2006-01-02")
-	logPath := filepath.Join(logDir, fmt.Sprintf("receipt_processor_%s.log", currentTime))
-
-	var err error
-	logFile, err = os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		log.Fatalf("Failed to open log file: %v", err)
+	writer := &lumberjack.Logger{
+		Filename:   filepath.Join(logDir, "receipt_processor.log"),
+		MaxSize:    100, // megabytes
+		MaxBackups: 5,
+		MaxAge:     28, // days
+		Compress:   true,
 	}
 
-	// Create loggers with different prefixes for different log levels
-	infoLogger = log.New(logFile, "INFO: ", log.Ldate|log.Ltime)
-	warnLogger = log.New(logFile, "WARN: ", log.Ldate|log.Ltime)
-	errorLogger = log.New(logFile, "ERROR: ", log.Ldate|log.Ltime)
+	level := parseLevel(os.Getenv("LOG_LEVEL"))
+	// Every log line goes through one of the LogInfo/LogWarn/LogError
+	// wrapper functions below, so zerolog's default caller skip (which
+	// assumes Msg is called directly from the logging site) would always
+	// report this file. Skip one extra frame to land on the real caller.
+	logger = zerolog.New(writer).Level(level).With().Timestamp().
+		CallerWithSkipFrameCount(zerolog.CallerSkipFrameCount + 1).Logger()
 
-	LogInfo("Logger initialized", LogParams{"logPath": logPath})
+	LogInfo("Logger initialized", LogParams{"logDir": logDir, "level": level.String()})
 }
 
-// addFileInfo adds the file and line number to the log entry
-func addFileInfo() string {
-	_, file, line, ok := runtime.Caller(2)
-	if !ok {
-		file = "unknown"
-		line = 0
+func parseLevel(raw string) zerolog.Level {
+	level, err := zerolog.ParseLevel(strings.ToLower(raw))
+	// ParseLevel maps "" to NoLevel without an error, which would drop every
+	// info/warn/error line, so an unset LOG_LEVEL falls back to info too.
+	if err != nil || level == zerolog.NoLevel {
+		return zerolog.InfoLevel
 	}
-	file = filepath.Base(file)
-	return fmt.Sprintf("[%s:%d] ", file, line)
+	return level
 }
 
-// formatParams formats the log parameters into a string
-func formatParams(params LogParams) string {
-	if len(params) == 0 {
-		return ""
-	}
+// WithRequestID returns a copy of ctx carrying requestID, so log lines
+// produced via FromContext(ctx) are tagged with it.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
 
-	result := " {"
-	for k, v := range params {
-		result += fmt.Sprintf(" %s: %v,", k, v)
+// RequestIDFromContext returns the request ID stored in ctx, if any.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey).(string)
+	return id, ok
+}
+
+// RequestLogger logs the same way the package-level LogInfo/LogWarn/
+// LogError functions do, except every line also carries the request ID it
+// was created with.
+type RequestLogger struct {
+	zl zerolog.Logger
+}
+
+// FromContext returns a RequestLogger tagged with ctx's request ID, if the
+// context carries one.
+func FromContext(ctx context.Context) RequestLogger {
+	zl := logger
+	if requestID, ok := RequestIDFromContext(ctx); ok {
+		zl = zl.With().Str("request_id", requestID).Logger()
 	}
-	// Remove the trailing comma and add closing brace
-	result = result[:len(result)-1] + " }"
-	return result
+	return RequestLogger{zl: zl}
 }
 
-// LogInfo logs an info message
+// LogInfo logs an info message, with no request correlation. Prefer
+// FromContext(ctx).LogInfo inside a request handler so log lines share
+// that request's ID.
 func LogInfo(message string, params LogParams) {
-	fileInfo := addFileInfo()
-	formatted := formatParams(params)
-	infoLogger.Println(fileInfo + message + formatted)
-
-	fmt.Println("INFO: " + fileInfo + message + formatted)
+	withFields(logger.Info(), params).Msg(message)
 }
 
-// LogWarn logs a warning message
+// LogWarn logs a warning message, with no request correlation.
 func LogWarn(message string, params LogParams) {
-	fileInfo := addFileInfo()
-	formatted := formatParams(params)
-	warnLogger.Println(fileInfo + message + formatted)
-
-	fmt.Println("WARN: " + fileInfo + message + formatted)
+	withFields(logger.Warn(), params).Msg(message)
 }
 
-// LogError logs an error message
+// LogError logs an error message, with no request correlation.
 func LogError(message string, params LogParams) {
-	fileInfo := addFileInfo()
-	formatted := formatParams(params)
-	errorLogger.Println(fileInfo + message + formatted)
+	withFields(logger.Error(), params).Msg(message)
+}
 
-	fmt.Println("ERROR: " + fileInfo + message + formatted)
+// LogInfo logs an info message tagged with l's request ID.
+func (l RequestLogger) LogInfo(message string, params LogParams) {
+	withFields(l.zl.Info(), params).Msg(message)
 }
 
-// Close closes the log file
-func Close() {
-	if logFile != nil {
-		logFile.Close()
+// LogWarn logs a warning message tagged with l's request ID.
+func (l RequestLogger) LogWarn(message string, params LogParams) {
+	withFields(l.zl.Warn(), params).Msg(message)
+}
+
+// LogError logs an error message tagged with l's request ID.
+func (l RequestLogger) LogError(message string, params LogParams) {
+	withFields(l.zl.Error(), params).Msg(message)
+}
+
+func withFields(e *zerolog.Event, params LogParams) *zerolog.Event {
+	for k, v := range params {
+		e = e.Interface(k, v)
 	}
+	return e
 }
+
+// Close is a no-op kept for source compatibility; lumberjack closes its
+// file on process exit.
+func Close() {}
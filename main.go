@@ -1,16 +1,36 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
 	"receipt-processor/handlers"
 	"receipt-processor/logging"
 	"receipt-processor/models"
+	"receipt-processor/store"
+	"strconv"
+	"syscall"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 )
 
+// requestIDHeader is the response header carrying the correlation ID
+// generated for each request.
+const requestIDHeader = "X-Request-ID"
+
+// defaultRequestTimeout bounds how long a single request may run before it
+// is canceled, configurable via the REQUEST_TIMEOUT env var (seconds).
+const defaultRequestTimeout = 5 * time.Second
+
+// shutdownGracePeriod bounds how long the server waits for in-flight
+// requests to drain after receiving SIGTERM/SIGINT.
+const shutdownGracePeriod = 10 * time.Second
+
 func main() {
 	// Initialize the logger
 	const logDir = "logs"
@@ -20,35 +40,109 @@ func main() {
 	// Create a new router
 	router := mux.NewRouter()
 
-	// Add logging middleware
+	// Add request-ID, logging, and request-deadline middleware
+	router.Use(requestIDMiddleware)
 	router.Use(loggingMiddleware)
+	router.Use(timeoutMiddleware(requestTimeout()))
 
-	// Create a receipt store
-	store := models.NewReceiptStore()
+	// Create the configured receipt store
+	receiptStore, err := newStore()
+	if err != nil {
+		logging.LogError("Failed to initialize storage backend", logging.LogParams{"error": err.Error()})
+		log.Fatal(err)
+	}
 
 	// Create a receipt handler
-	receiptHandler := handlers.NewReceiptHandler(store)
+	receiptHandler := handlers.NewReceiptHandler(receiptStore)
 
 	// Register routes
 	router.HandleFunc("/receipts/process", receiptHandler.ProcessReceipt).Methods("POST")
+	router.HandleFunc("/receipts/process/bulk", receiptHandler.BulkProcessReceipts).Methods("POST")
 	router.HandleFunc("/receipts/{id}/points", receiptHandler.GetPoints).Methods("GET")
+	router.HandleFunc("/receipts", receiptHandler.ListReceipts).Methods("GET", "POST")
 
 	// Start the server
 	port := 8080
-	serverAddr := fmt.Sprintf(":%d", port)
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%d", port),
+		Handler: router,
+	}
+
 	logging.LogInfo("Server starting", logging.LogParams{"port": port})
 	fmt.Printf("Server starting on port %d...\n", port)
 
-	if err := http.ListenAndServe(serverAddr, router); err != nil {
+	serverErr := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			serverErr <- err
+		}
+		close(serverErr)
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
+
+	select {
+	case err := <-serverErr:
 		logging.LogError("Server failed to start", logging.LogParams{"error": err.Error()})
 		log.Fatal(err)
+	case sig := <-stop:
+		logging.LogInfo("Shutting down", logging.LogParams{"signal": sig.String()})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownGracePeriod)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		logging.LogError("Graceful shutdown failed", logging.LogParams{"error": err.Error()})
+		log.Fatal(err)
+	}
+
+	logging.LogInfo("Server stopped", logging.LogParams{})
+}
+
+// newStore builds the models.Store backend selected by the STORAGE_DRIVER
+// env var (sqlite, postgres, or memory, the default). STORAGE_DSN provides
+// the connection string for SQL-backed drivers.
+func newStore() (models.Store, error) {
+	driver := os.Getenv("STORAGE_DRIVER")
+	switch store.Driver(driver) {
+	case store.DriverSQLite, store.DriverPostgres:
+		return store.NewSQLStore(store.Driver(driver), os.Getenv("STORAGE_DSN"))
+	case "", "memory":
+		return models.NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_DRIVER %q", driver)
 	}
 }
 
+// requestTimeout returns the per-request deadline, configurable via
+// REQUEST_TIMEOUT (seconds), falling back to defaultRequestTimeout.
+func requestTimeout() time.Duration {
+	if v := os.Getenv("REQUEST_TIMEOUT"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return defaultRequestTimeout
+}
+
+// requestIDMiddleware tags each request with a UUID, so every log line
+// produced while handling it can be correlated via logging.FromContext. The
+// ID is echoed back in the X-Request-ID response header.
+func requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := uuid.New().String()
+		w.Header().Set(requestIDHeader, requestID)
+		ctx := logging.WithRequestID(r.Context(), requestID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 // loggingMiddleware logs each request
 func loggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		logging.LogInfo("Request received", logging.LogParams{
+		logging.FromContext(r.Context()).LogInfo("Request received", logging.LogParams{
 			"method": r.Method,
 			"path":   r.URL.Path,
 			"remote": r.RemoteAddr,
@@ -56,3 +150,15 @@ func loggingMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// timeoutMiddleware bounds each request to timeout, so a slow handler or
+// store operation is canceled instead of running indefinitely.
+func timeoutMiddleware(timeout time.Duration) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"regexp"
 	"time"
 )
@@ -14,14 +15,18 @@ const (
 	ErrMissingField  ErrorCode = "MISSING_FIELD"
 	ErrInvalidFormat ErrorCode = "INVALID_FORMAT"
 	ErrInvalidValue  ErrorCode = "INVALID_VALUE"
+	ErrBatchTooLarge ErrorCode = "BATCH_TOO_LARGE"
+	ErrTimeout       ErrorCode = "TIMEOUT"
+	ErrUnavailable   ErrorCode = "UNAVAILABLE"
 )
 
 // ValidationError represents a validation error with additional context
 type ValidationError struct {
-	Code    ErrorCode
-	Message string
-	Field   string
-	Value   interface{}
+	Code    ErrorCode   `json:"code"`
+	Message string      `json:"message"`
+	Field   string      `json:"field,omitempty"`
+	Value   interface{} `json:"value,omitempty"`
+	Status  int         `json:"-"`
 }
 
 func (e *ValidationError) Error() string {
@@ -66,6 +71,22 @@ func (e *ValidationError) WithValue(value interface{}) *ValidationError {
 	return e
 }
 
+// WithStatus overrides the HTTP status code used to report the error,
+// which otherwise defaults to 400 Bad Request.
+func (e *ValidationError) WithStatus(status int) *ValidationError {
+	e.Status = status
+	return e
+}
+
+// StatusCode returns the HTTP status code this error should be reported
+// with.
+func (e *ValidationError) StatusCode() int {
+	if e.Status != 0 {
+		return e.Status
+	}
+	return http.StatusBadRequest
+}
+
 // IsValidationError checks if an error is a ValidationError
 func IsValidationError(err error) bool {
 	_, ok := err.(*ValidationError)
@@ -97,6 +118,23 @@ func NewInvalidValueError(field string, value interface{}) *ValidationError {
 	return NewValidationError(ErrInvalidValue, "invalid value").WithField(field).WithValue(value)
 }
 
+func NewBatchTooLargeError(size, limit int) *ValidationError {
+	message := fmt.Sprintf("batch of %d receipts exceeds maximum of %d", size, limit)
+	return NewValidationError(ErrBatchTooLarge, message).WithStatus(http.StatusRequestEntityTooLarge)
+}
+
+// NewTimeoutError reports that a request's deadline elapsed before it
+// could complete.
+func NewTimeoutError() *ValidationError {
+	return NewValidationError(ErrTimeout, "request deadline exceeded").WithStatus(http.StatusGatewayTimeout)
+}
+
+// NewUnavailableError reports that a request was canceled, e.g. because
+// the client disconnected or the server is shutting down.
+func NewUnavailableError() *ValidationError {
+	return NewValidationError(ErrUnavailable, "request canceled").WithStatus(http.StatusServiceUnavailable)
+}
+
 func IsValidDateFormat(date string) bool {
 	_, err := time.Parse("2006-01-02", date)
 	return err == nil
@@ -0,0 +1,186 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MemoryStore is an in-memory Store implementation. It does not persist
+// data across restarts, so it is mainly useful for local development and
+// tests; operators who need durability should use the SQL-backed store
+// instead (see the store package). A mutex guards Receipts since it is
+// the default backend and handlers may call it from concurrent requests.
+type MemoryStore struct {
+	mu       sync.RWMutex
+	Receipts map[string]Receipt
+}
+
+// NewMemoryStore creates a new, empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		Receipts: make(map[string]Receipt),
+	}
+}
+
+// AddReceipt adds a receipt to the store and returns the ID
+func (rs *MemoryStore) AddReceipt(ctx context.Context, receipt Receipt) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	id := uuid.New().String()
+	receipt.ID = id
+	receipt.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	rs.mu.Lock()
+	rs.Receipts[id] = receipt
+	rs.mu.Unlock()
+	return id, nil
+}
+
+// GetReceipt gets a receipt from the store by ID
+func (rs *MemoryStore) GetReceipt(ctx context.Context, id string) (Receipt, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return Receipt{}, false, err
+	}
+	rs.mu.RLock()
+	receipt, ok := rs.Receipts[id]
+	rs.mu.RUnlock()
+	return receipt, ok, nil
+}
+
+// ListReceipts returns the receipts matching filter, paged and ordered
+// according to paging, along with the total number of matching receipts.
+func (rs *MemoryStore) ListReceipts(ctx context.Context, filter ReceiptFilter, paging Paging) ([]Receipt, int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	rs.mu.RLock()
+	matched := make([]Receipt, 0, len(rs.Receipts))
+	for _, receipt := range rs.Receipts {
+		if matchesFilter(receipt, filter) {
+			matched = append(matched, receipt)
+		}
+	}
+	rs.mu.RUnlock()
+
+	sortReceipts(matched, paging.OrderBy, paging.SortDirection)
+
+	totalCount := len(matched)
+	start := (paging.Page - 1) * paging.PageSize
+	if start > totalCount {
+		start = totalCount
+	}
+	end := start + paging.PageSize
+	if end > totalCount {
+		end = totalCount
+	}
+
+	return matched[start:end], totalCount, nil
+}
+
+func matchesFilter(receipt Receipt, filter ReceiptFilter) bool {
+	if filter.Retailer != "" && !strings.Contains(strings.ToLower(receipt.Retailer), strings.ToLower(filter.Retailer)) {
+		return false
+	}
+	if filter.PurchaseDateFrom != "" && receipt.PurchaseDate < filter.PurchaseDateFrom {
+		return false
+	}
+	if filter.PurchaseDateTo != "" && receipt.PurchaseDate > filter.PurchaseDateTo {
+		return false
+	}
+	if filter.CreatedAtFrom != "" && receipt.CreatedAt < filter.CreatedAtFrom {
+		return false
+	}
+	if filter.CreatedAtTo != "" && receipt.CreatedAt > filter.CreatedAtTo {
+		return false
+	}
+	if total, err := strconv.ParseFloat(receipt.Total, 64); err == nil {
+		if filter.TotalMin != nil && total < *filter.TotalMin {
+			return false
+		}
+		if filter.TotalMax != nil && total > *filter.TotalMax {
+			return false
+		}
+	}
+	if filter.PointsMin != nil && receipt.Points < *filter.PointsMin {
+		return false
+	}
+	if filter.PointsMax != nil && receipt.Points > *filter.PointsMax {
+		return false
+	}
+	return true
+}
+
+// sortReceipts orders receipts in place by orderBy ("retailer", "total",
+// "points", "createdAt", or "purchaseDate", the default). sortDirection
+// "desc" reverses the order; anything else (including "") sorts ascending.
+func sortReceipts(receipts []Receipt, orderBy, sortDirection string) {
+	desc := sortDirection == "desc"
+
+	less := func(i, j int) bool {
+		var result bool
+		switch orderBy {
+		case "retailer":
+			result = receipts[i].Retailer < receipts[j].Retailer
+		case "total":
+			result = totalAsFloat(receipts[i].Total) < totalAsFloat(receipts[j].Total)
+		case "points":
+			result = receipts[i].Points < receipts[j].Points
+		case "createdAt":
+			result = receipts[i].CreatedAt < receipts[j].CreatedAt
+		default:
+			result = receipts[i].PurchaseDate < receipts[j].PurchaseDate
+		}
+		if desc {
+			return !result
+		}
+		return result
+	}
+
+	sort.SliceStable(receipts, less)
+}
+
+// totalAsFloat parses a receipt's Total for numeric comparison, so
+// orderBy=total sorts by value instead of lexically (which would put
+// "100.00" before "20.00"). An unparsable total sorts as 0.
+func totalAsFloat(total string) float64 {
+	v, _ := strconv.ParseFloat(total, 64)
+	return v
+}
+
+// UpdateReceipt replaces the receipt stored under id.
+func (rs *MemoryStore) UpdateReceipt(ctx context.Context, id string, receipt Receipt) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	existing, ok := rs.Receipts[id]
+	if !ok {
+		return fmt.Errorf("receipt %s not found", id)
+	}
+	receipt.ID = id
+	receipt.CreatedAt = existing.CreatedAt
+	rs.Receipts[id] = receipt
+	return nil
+}
+
+// DeleteReceipt removes the receipt stored under id.
+func (rs *MemoryStore) DeleteReceipt(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if _, ok := rs.Receipts[id]; !ok {
+		return fmt.Errorf("receipt %s not found", id)
+	}
+	delete(rs.Receipts, id)
+	return nil
+}
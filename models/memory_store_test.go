@@ -0,0 +1,120 @@
+package models
+
+import (
+	"context"
+	"testing"
+)
+
+func seedStore(t *testing.T, rs *MemoryStore, receipts []Receipt) {
+	t.Helper()
+	for _, r := range receipts {
+		if _, err := rs.AddReceipt(context.Background(), r); err != nil {
+			t.Fatalf("AddReceipt: %v", err)
+		}
+	}
+}
+
+func TestMemoryStoreListReceiptsPaging(t *testing.T) {
+	rs := NewMemoryStore()
+	seedStore(t, rs, []Receipt{
+		{Retailer: "Target", PurchaseDate: "2022-01-01", Total: "10.00"},
+		{Retailer: "Walmart", PurchaseDate: "2022-01-02", Total: "20.00"},
+		{Retailer: "Costco", PurchaseDate: "2022-01-03", Total: "30.00"},
+	})
+
+	got, total, err := rs.ListReceipts(context.Background(), ReceiptFilter{}, Paging{Page: 1, PageSize: 2, OrderBy: "purchaseDate"})
+	if err != nil {
+		t.Fatalf("ListReceipts: %v", err)
+	}
+	if total != 3 {
+		t.Fatalf("totalCount = %d, want 3", total)
+	}
+	if len(got) != 2 {
+		t.Fatalf("len(page 1) = %d, want 2", len(got))
+	}
+	if got[0].Retailer != "Target" || got[1].Retailer != "Walmart" {
+		t.Errorf("page 1 = %+v, want [Target, Walmart]", got)
+	}
+
+	got, total, err = rs.ListReceipts(context.Background(), ReceiptFilter{}, Paging{Page: 2, PageSize: 2, OrderBy: "purchaseDate"})
+	if err != nil {
+		t.Fatalf("ListReceipts: %v", err)
+	}
+	if total != 3 {
+		t.Fatalf("totalCount = %d, want 3", total)
+	}
+	if len(got) != 1 || got[0].Retailer != "Costco" {
+		t.Errorf("page 2 = %+v, want [Costco]", got)
+	}
+
+	got, _, err = rs.ListReceipts(context.Background(), ReceiptFilter{}, Paging{Page: 3, PageSize: 2, OrderBy: "purchaseDate"})
+	if err != nil {
+		t.Fatalf("ListReceipts: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("page past the end = %+v, want empty", got)
+	}
+}
+
+func TestMemoryStoreListReceiptsFilter(t *testing.T) {
+	rs := NewMemoryStore()
+	seedStore(t, rs, []Receipt{
+		{Retailer: "Target", PurchaseDate: "2022-01-01", Total: "10.00", Points: 5},
+		{Retailer: "Walmart", PurchaseDate: "2022-01-02", Total: "20.00", Points: 15},
+		{Retailer: "Costco", PurchaseDate: "2022-01-03", Total: "30.00", Points: 25},
+	})
+
+	tests := []struct {
+		name   string
+		filter ReceiptFilter
+		want   []string
+	}{
+		{
+			name:   "retailer substring, case-insensitive",
+			filter: ReceiptFilter{Retailer: "wal"},
+			want:   []string{"Walmart"},
+		},
+		{
+			name:   "purchase date range",
+			filter: ReceiptFilter{PurchaseDateFrom: "2022-01-02", PurchaseDateTo: "2022-01-03"},
+			want:   []string{"Walmart", "Costco"},
+		},
+		{
+			name:   "points min",
+			filter: ReceiptFilter{PointsMin: intPtr(15)},
+			want:   []string{"Walmart", "Costco"},
+		},
+		{
+			name:   "points max",
+			filter: ReceiptFilter{PointsMax: intPtr(15)},
+			want:   []string{"Target", "Walmart"},
+		},
+		{
+			name:   "no matches",
+			filter: ReceiptFilter{Retailer: "nonexistent"},
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, total, err := rs.ListReceipts(context.Background(), tt.filter, Paging{Page: 1, PageSize: 10, OrderBy: "purchaseDate"})
+			if err != nil {
+				t.Fatalf("ListReceipts: %v", err)
+			}
+			if total != len(tt.want) {
+				t.Errorf("totalCount = %d, want %d", total, len(tt.want))
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d receipts, want %d", len(got), len(tt.want))
+			}
+			for i, r := range got {
+				if r.Retailer != tt.want[i] {
+					t.Errorf("got[%d].Retailer = %s, want %s", i, r.Retailer, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func intPtr(n int) *int { return &n }
@@ -0,0 +1,47 @@
+// Package money provides exact decimal arithmetic for currency amounts.
+// Plain float64 math (as strconv.ParseFloat + multiplication) suffers from
+// binary-float rounding drift that can misclassify totals near
+// quarter-dollar boundaries, so every scoring rule that touches a currency
+// amount should go through here instead.
+package money
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// Amount is a currency value backed by an exact decimal representation.
+type Amount struct {
+	decimal.Decimal
+}
+
+// Parse parses a currency string previously validated by
+// models.IsValidCurrencyFormat (e.g. "9.99") into an Amount.
+func Parse(value string) (Amount, error) {
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		return Amount{}, fmt.Errorf("money: invalid amount %q: %w", value, err)
+	}
+	return Amount{d}, nil
+}
+
+// IsRoundDollar reports whether the amount has no cents, e.g. "12.00".
+func (a Amount) IsRoundDollar() bool {
+	return a.Mod(decimal.NewFromInt(1)).IsZero()
+}
+
+// IsMultipleOf reports whether the amount is an exact multiple of quantum,
+// e.g. quantum "0.25" to check for a multiple of a quarter.
+func (a Amount) IsMultipleOf(quantum decimal.Decimal) bool {
+	if quantum.IsZero() {
+		return false
+	}
+	return a.Mod(quantum).IsZero()
+}
+
+// MulCeil multiplies the amount by factor and rounds the result up to the
+// nearest integer.
+func (a Amount) MulCeil(factor decimal.Decimal) int {
+	return int(a.Mul(factor).Ceil().IntPart())
+}
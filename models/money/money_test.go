@@ -0,0 +1,116 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "round dollar", value: "9.00", want: "9"},
+		{name: "two decimal places", value: "9.99", want: "9.99"},
+		{name: "quarter multiple", value: "1.75", want: "1.75"},
+		{name: "thirty cents", value: "0.30", want: "0.3"},
+		{name: "invalid", value: "not-a-number", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Parse(%q) = nil error, want one", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.value, err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("Parse(%q) = %s, want %s", tt.value, got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestIsRoundDollar(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "9.00", want: true},
+		{value: "12.00", want: true},
+		{value: "9.99", want: false},
+		{value: "1.75", want: false},
+		{value: "0.30", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			amount, err := Parse(tt.value)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.value, err)
+			}
+			if got := amount.IsRoundDollar(); got != tt.want {
+				t.Errorf("IsRoundDollar(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsMultipleOf(t *testing.T) {
+	quarterDollar := decimal.RequireFromString("0.25")
+
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "9.00", want: true},
+		{value: "1.75", want: true},
+		{value: "9.99", want: false},
+		{value: "0.30", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			amount, err := Parse(tt.value)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.value, err)
+			}
+			if got := amount.IsMultipleOf(quarterDollar); got != tt.want {
+				t.Errorf("IsMultipleOf(%q, 0.25) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMulCeil(t *testing.T) {
+	itemPriceFactor := decimal.RequireFromString("0.2")
+
+	tests := []struct {
+		value string
+		want  int
+	}{
+		{value: "9.99", want: 2},
+		{value: "1.75", want: 1},
+		{value: "0.30", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			amount, err := Parse(tt.value)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.value, err)
+			}
+			if got := amount.MulCeil(itemPriceFactor); got != tt.want {
+				t.Errorf("MulCeil(%q, 0.2) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
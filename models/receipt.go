@@ -1,14 +1,20 @@
 package models
 
 import (
-	"math"
-	"regexp"
-	"strconv"
 	"strings"
 	"time"
 	"unicode"
 
-	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"receipt-processor/models/money"
+)
+
+// quarterDollar and itemPriceFactor are the exact decimal constants used
+// by the scoring rules below.
+var (
+	quarterDollar   = decimal.RequireFromString("0.25")
+	itemPriceFactor = decimal.RequireFromString("0.2")
 )
 
 // Item represents an item on a receipt
@@ -26,6 +32,7 @@ type Receipt struct {
 	Items        []Item `json:"items"`
 	Total        string `json:"total"`
 	Points       int    `json:"points,omitempty"`
+	CreatedAt    string `json:"createdAt,omitempty"`
 }
 
 // ProcessResponse is the response from the process endpoint
@@ -38,30 +45,31 @@ type PointsResponse struct {
 	Points int `json:"points"`
 }
 
-// ReceiptStore is an in-memory store for receipts
-type ReceiptStore struct {
-	Receipts map[string]Receipt
+// ReceiptListResponse is the response from the list-receipts endpoint
+type ReceiptListResponse struct {
+	Items      []Receipt `json:"items"`
+	Page       int       `json:"page"`
+	PageSize   int       `json:"pageSize"`
+	TotalCount int       `json:"totalCount"`
 }
 
-// NewReceiptStore creates a new receipt store
-func NewReceiptStore() *ReceiptStore {
-	return &ReceiptStore{
-		Receipts: make(map[string]Receipt),
-	}
+// BulkProcessRequest is the request body for the bulk receipt processing endpoint
+type BulkProcessRequest struct {
+	Receipts []Receipt `json:"receipts"`
 }
 
-// AddReceipt adds a receipt to the store and returns the ID
-func (rs *ReceiptStore) AddReceipt(receipt Receipt) string {
-	id := uuid.New().String()
-	receipt.ID = id
-	rs.Receipts[id] = receipt
-	return id
+// BulkProcessResult is one entry in a bulk receipt processing response. A
+// receipt that failed validation carries Error instead of ID/Points.
+type BulkProcessResult struct {
+	Index  int              `json:"index"`
+	ID     string           `json:"id,omitempty"`
+	Points int              `json:"points,omitempty"`
+	Error  *ValidationError `json:"error,omitempty"`
 }
 
-// GetReceipt gets a receipt from the store by ID
-func (rs *ReceiptStore) GetReceipt(id string) (Receipt, bool) {
-	receipt, ok := rs.Receipts[id]
-	return receipt, ok
+// BulkProcessResponse is the response from the bulk receipt processing endpoint
+type BulkProcessResponse struct {
+	Results []BulkProcessResult `json:"results"`
 }
 
 // CalculatePoints calculates the points for a receipt
@@ -71,14 +79,16 @@ func CalculatePoints(receipt Receipt) int {
 	// Rule 1: One point for every alphanumeric character in the retailer name
 	points += countAlphanumeric(receipt.Retailer)
 
-	// Rule 2: 50 points if the total is a round dollar amount with no cents
-	if isRoundDollarAmount(receipt.Total) {
-		points += 50
-	}
-
-	// Rule 3: 25 points if the total is a multiple of 0.25
-	if isMultipleOf25Cents(receipt.Total) {
-		points += 25
+	// Rule 2 & 3: 50 points if the total is a round dollar amount with no
+	// cents, plus 25 points if it's a multiple of 0.25. Parsed once via the
+	// money package so these checks are exact, not float64 approximations.
+	if total, err := money.Parse(receipt.Total); err == nil {
+		if total.IsRoundDollar() {
+			points += 50
+		}
+		if total.IsMultipleOf(quarterDollar) {
+			points += 25
+		}
 	}
 
 	// Rule 4: 5 points for every two items on the receipt
@@ -89,10 +99,8 @@ func CalculatePoints(receipt Receipt) int {
 	for _, item := range receipt.Items {
 		trimmedDesc := strings.TrimSpace(item.ShortDescription)
 		if len(trimmedDesc)%3 == 0 && len(trimmedDesc) > 0 {
-			price, err := strconv.ParseFloat(item.Price, 64)
-			if err == nil {
-				pointsForItem := int(math.Ceil(price * 0.2))
-				points += pointsForItem
+			if price, err := money.Parse(item.Price); err == nil {
+				points += price.MulCeil(itemPriceFactor)
 			}
 		}
 	}
@@ -121,24 +129,6 @@ func countAlphanumeric(s string) int {
 	return count
 }
 
-// isRoundDollarAmount checks if the total is a round dollar amount
-func isRoundDollarAmount(total string) bool {
-	re := regexp.MustCompile(`^\d+\.00`)
-	return re.MatchString(total)
-}
-
-// isMultipleOf25Cents checks if the total is a multiple of 0.25
-func isMultipleOf25Cents(total string) bool {
-	val, err := strconv.ParseFloat(total, 64)
-	if err != nil {
-		return false
-	}
-
-	// Convert to cents and check if it's a multiple of 25
-	cents := int(val * 100)
-	return cents%25 == 0
-}
-
 // isDayOdd checks if the day in the purchase date is odd
 func isDayOdd(purchaseDate string) bool {
 	t, err := time.Parse("2006-01-02", purchaseDate)
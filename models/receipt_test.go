@@ -0,0 +1,70 @@
+package models
+
+import "testing"
+
+func TestCalculatePoints(t *testing.T) {
+	tests := []struct {
+		name    string
+		receipt Receipt
+		want    int
+	}{
+		{
+			// Retailer "M&M" -> 2 alphanumeric chars. Total 9.99 is neither a
+			// round dollar nor a multiple of 0.25, so neither bonus applies.
+			// One item, "Gum" trims to length 3 -> rule 5: 9.99 * 0.2 =
+			// 1.998, ceil to 2 points. Odd day (1) -> 6 points. Time outside
+			// the 2-4pm window -> no bonus.
+			name: "9.99 total does not round-dollar or quarter-dollar",
+			receipt: Receipt{
+				Retailer:     "M&M",
+				PurchaseDate: "2022-01-01",
+				PurchaseTime: "13:01",
+				Items: []Item{
+					{ShortDescription: "Gum", Price: "9.99"},
+				},
+				Total: "9.99",
+			},
+			want: 2 + 2 + 6,
+		},
+		{
+			// Total 1.75 is a multiple of 0.25 but not a round dollar.
+			// Description "Dr Pepper - 12oz" trims to length 16, not a
+			// multiple of 3, so rule 5 doesn't apply either.
+			name: "1.75 total is a quarter-dollar multiple",
+			receipt: Receipt{
+				Retailer:     "M&M",
+				PurchaseDate: "2022-01-02",
+				PurchaseTime: "13:01",
+				Items: []Item{
+					{ShortDescription: "Dr Pepper - 12oz", Price: "1.75"},
+				},
+				Total: "1.75",
+			},
+			want: 2 + 25,
+		},
+		{
+			// Description "Gum" trims to length 3, a multiple of 3, so rule
+			// 5 applies: 0.30 * 0.2 = 0.06, ceil to 1 point. Total 0.30 is
+			// neither round-dollar nor a quarter-dollar multiple.
+			name: "0.30 item price exercises the rule-5 ceiling",
+			receipt: Receipt{
+				Retailer:     "M&M",
+				PurchaseDate: "2022-01-02",
+				PurchaseTime: "13:01",
+				Items: []Item{
+					{ShortDescription: "Gum", Price: "0.30"},
+				},
+				Total: "0.30",
+			},
+			want: 2 + 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CalculatePoints(tt.receipt); got != tt.want {
+				t.Errorf("CalculatePoints() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
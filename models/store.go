@@ -0,0 +1,60 @@
+package models
+
+import "context"
+
+// Paging describes which page of a result set to return, and how to order
+// it.
+type Paging struct {
+	Page          int
+	PageSize      int
+	OrderBy       string
+	SortDirection string
+}
+
+// ReceiptFilter narrows a ListReceipts query to receipts matching the given
+// criteria. Zero-valued fields are not applied.
+type ReceiptFilter struct {
+	Retailer         string
+	PurchaseDateFrom string
+	PurchaseDateTo   string
+	CreatedAtFrom    string
+	CreatedAtTo      string
+	TotalMin         *float64
+	TotalMax         *float64
+	PointsMin        *int
+	PointsMax        *int
+}
+
+// Store persists receipts and their calculated points. Implementations may
+// be in-memory (see MemoryStore) or backed by a SQL database (see the store
+// package), so handlers can swap backends without changing their logic.
+// Every method takes ctx so a request deadline or client disconnect can
+// cancel work in progress, including in-flight SQL queries.
+type Store interface {
+	// AddReceipt persists receipt and returns the ID it was assigned.
+	AddReceipt(ctx context.Context, receipt Receipt) (string, error)
+
+	// GetReceipt looks up a receipt by ID. The second return value reports
+	// whether a receipt with that ID was found.
+	GetReceipt(ctx context.Context, id string) (Receipt, bool, error)
+
+	// ListReceipts returns the receipts matching filter, paged and ordered
+	// according to paging, along with the total number of matching
+	// receipts across all pages.
+	ListReceipts(ctx context.Context, filter ReceiptFilter, paging Paging) ([]Receipt, int, error)
+
+	// UpdateReceipt replaces the receipt stored under id.
+	UpdateReceipt(ctx context.Context, id string, receipt Receipt) error
+
+	// DeleteReceipt removes the receipt stored under id.
+	DeleteReceipt(ctx context.Context, id string) error
+}
+
+// BulkStore is implemented by stores that can persist multiple receipts in a
+// single transaction. Stores that don't implement it (e.g. MemoryStore) are
+// added to one at a time instead.
+type BulkStore interface {
+	// AddReceipts persists receipts and returns the IDs assigned to them,
+	// in the same order.
+	AddReceipts(ctx context.Context, receipts []Receipt) ([]string, error)
+}
@@ -0,0 +1,111 @@
+// Package service contains the business logic behind the receipt-processor
+// API, decoupled from HTTP concerns so handlers stay thin wrappers around
+// commands.Command and a ReceiptService.
+package service
+
+import (
+	"context"
+
+	"receipt-processor/commands"
+	"receipt-processor/models"
+)
+
+// ReceiptService implements the receipt-processor use cases on top of a
+// models.Store.
+type ReceiptService struct {
+	Store models.Store
+}
+
+// NewReceiptService creates a ReceiptService backed by store.
+func NewReceiptService(store models.Store) *ReceiptService {
+	return &ReceiptService{Store: store}
+}
+
+// ProcessReceipt scores and persists a single receipt.
+func (s *ReceiptService) ProcessReceipt(ctx context.Context, cmd *commands.ProcessReceiptCommand) (models.ProcessResponse, error) {
+	receipt := cmd.Receipt
+	receipt.Points = models.CalculatePoints(receipt)
+
+	id, err := s.Store.AddReceipt(ctx, receipt)
+	if err != nil {
+		return models.ProcessResponse{}, err
+	}
+	return models.ProcessResponse{ID: id}, nil
+}
+
+// GetPoints looks up the points previously calculated for a receipt. The
+// second return value reports whether the receipt was found.
+func (s *ReceiptService) GetPoints(ctx context.Context, cmd *commands.GetPointsCommand) (models.PointsResponse, bool, error) {
+	receipt, found, err := s.Store.GetReceipt(ctx, cmd.ID)
+	if err != nil || !found {
+		return models.PointsResponse{}, found, err
+	}
+	return models.PointsResponse{Points: receipt.Points}, true, nil
+}
+
+// ListReceipts returns a paged, filtered view of stored receipts.
+func (s *ReceiptService) ListReceipts(ctx context.Context, cmd *commands.ListReceiptsCommand) (models.ReceiptListResponse, error) {
+	items, totalCount, err := s.Store.ListReceipts(ctx, cmd.Filter, cmd.Paging())
+	if err != nil {
+		return models.ReceiptListResponse{}, err
+	}
+	return models.ReceiptListResponse{
+		Items:      items,
+		Page:       cmd.Page,
+		PageSize:   cmd.PageSize,
+		TotalCount: totalCount,
+	}, nil
+}
+
+// BulkProcessReceipts validates, scores, and stores a batch of receipts.
+// Each receipt is handled independently, so one invalid receipt does not
+// fail the rest of the batch.
+func (s *ReceiptService) BulkProcessReceipts(ctx context.Context, cmd *commands.BulkProcessCommand) (models.BulkProcessResponse, error) {
+	results := make([]models.BulkProcessResult, len(cmd.Receipts))
+	toStore := make([]models.Receipt, 0, len(cmd.Receipts))
+	toStoreIndexes := make([]int, 0, len(cmd.Receipts))
+
+	for i, receipt := range cmd.Receipts {
+		if err := commands.ValidateReceipt(receipt); err != nil {
+			results[i] = models.BulkProcessResult{Index: i, Error: models.GetValidationError(err)}
+			continue
+		}
+		receipt.Points = models.CalculatePoints(receipt)
+		toStore = append(toStore, receipt)
+		toStoreIndexes = append(toStoreIndexes, i)
+	}
+
+	ids, err := s.addReceipts(ctx, toStore)
+	if err != nil {
+		return models.BulkProcessResponse{}, err
+	}
+
+	for j, id := range ids {
+		i := toStoreIndexes[j]
+		results[i] = models.BulkProcessResult{Index: i, ID: id, Points: toStore[j].Points}
+	}
+
+	return models.BulkProcessResponse{Results: results}, nil
+}
+
+// addReceipts stores receipts in a single transaction when the backing
+// store supports it (see models.BulkStore), falling back to adding them one
+// at a time otherwise.
+func (s *ReceiptService) addReceipts(ctx context.Context, receipts []models.Receipt) ([]string, error) {
+	if len(receipts) == 0 {
+		return nil, nil
+	}
+	if bulk, ok := s.Store.(models.BulkStore); ok {
+		return bulk.AddReceipts(ctx, receipts)
+	}
+
+	ids := make([]string, len(receipts))
+	for i, receipt := range receipts {
+		id, err := s.Store.AddReceipt(ctx, receipt)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
@@ -0,0 +1,458 @@
+// Package store provides a SQL-backed implementation of models.Store so
+// that receipts survive process restarts.
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"receipt-processor/models"
+
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Driver identifies which SQL dialect backs a SQLStore.
+type Driver string
+
+const (
+	DriverSQLite   Driver = "sqlite"
+	DriverPostgres Driver = "postgres"
+)
+
+var sqlDriverNames = map[Driver]string{
+	DriverSQLite:   "sqlite3",
+	DriverPostgres: "postgres",
+}
+
+// SQLStore is a models.Store backed by SQLite or PostgreSQL. It persists
+// the receipt header, its items, and the calculated points, along with a
+// created-at timestamp, so historical data can be queried after a restart.
+// All operations take a context.Context so a request deadline or client
+// disconnect cancels the underlying query.
+type SQLStore struct {
+	db     *sql.DB
+	driver Driver
+}
+
+// NewSQLStore opens dsn using driver and runs migrations before returning.
+func NewSQLStore(driver Driver, dsn string) (*SQLStore, error) {
+	driverName, ok := sqlDriverNames[driver]
+	if !ok {
+		return nil, fmt.Errorf("store: unsupported driver %q", driver)
+	}
+
+	if driver == DriverSQLite {
+		dsn = withSQLiteForeignKeys(dsn)
+	}
+
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("store: open %s: %w", driver, err)
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("store: ping %s: %w", driver, err)
+	}
+
+	s := &SQLStore{db: db, driver: driver}
+	if err := s.migrate(); err != nil {
+		return nil, fmt.Errorf("store: migrate: %w", err)
+	}
+	return s, nil
+}
+
+// withSQLiteForeignKeys appends the mattn/go-sqlite3 query parameter that
+// turns on per-connection foreign key enforcement. SQLite ignores
+// REFERENCES ... ON DELETE CASCADE unless this is set, which would
+// otherwise orphan receipt_items rows on DeleteReceipt.
+func withSQLiteForeignKeys(dsn string) string {
+	sep := "?"
+	if strings.Contains(dsn, "?") {
+		sep = "&"
+	}
+	return dsn + sep + "_foreign_keys=on"
+}
+
+// Close releases the underlying database connection.
+func (s *SQLStore) Close() error {
+	return s.db.Close()
+}
+
+// migrate creates the schema if it does not already exist.
+func (s *SQLStore) migrate() error {
+	itemsID := "INTEGER PRIMARY KEY AUTOINCREMENT"
+	if s.driver == DriverPostgres {
+		itemsID = "SERIAL PRIMARY KEY"
+	}
+
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS receipts (
+			id TEXT PRIMARY KEY,
+			retailer TEXT NOT NULL,
+			purchase_date TEXT NOT NULL,
+			purchase_time TEXT NOT NULL,
+			total TEXT NOT NULL,
+			points INTEGER NOT NULL,
+			created_at TIMESTAMP NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS receipt_items (
+			id ` + itemsID + `,
+			receipt_id TEXT NOT NULL REFERENCES receipts(id) ON DELETE CASCADE,
+			position INTEGER NOT NULL,
+			short_description TEXT NOT NULL,
+			price TEXT NOT NULL
+		)`,
+	}
+
+	for _, stmt := range statements {
+		if _, err := s.db.Exec(s.bind(stmt)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bind rewrites `?` placeholders into `$1`, `$2`, ... for drivers (like
+// PostgreSQL) that don't accept positional placeholders.
+func (s *SQLStore) bind(query string) string {
+	if s.driver != DriverPostgres {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// AddReceipt persists receipt, along with its items, in a single transaction.
+func (s *SQLStore) AddReceipt(ctx context.Context, receipt models.Receipt) (string, error) {
+	id := uuid.New().String()
+	receipt.ID = id
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("store: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := s.insertReceipt(ctx, tx, receipt, time.Now().UTC()); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("store: commit: %w", err)
+	}
+	return id, nil
+}
+
+// AddReceipts persists receipts in a single transaction, rolling all of
+// them back if any insert fails.
+func (s *SQLStore) AddReceipts(ctx context.Context, receipts []models.Receipt) ([]string, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("store: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+	ids := make([]string, len(receipts))
+	for i, receipt := range receipts {
+		receipt.ID = uuid.New().String()
+		if err := s.insertReceipt(ctx, tx, receipt, now); err != nil {
+			return nil, err
+		}
+		ids[i] = receipt.ID
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("store: commit: %w", err)
+	}
+	return ids, nil
+}
+
+func (s *SQLStore) insertReceipt(ctx context.Context, tx *sql.Tx, receipt models.Receipt, createdAt time.Time) error {
+	_, err := tx.ExecContext(ctx,
+		s.bind(`INSERT INTO receipts (id, retailer, purchase_date, purchase_time, total, points, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)`),
+		receipt.ID, receipt.Retailer, receipt.PurchaseDate, receipt.PurchaseTime, receipt.Total, receipt.Points, createdAt,
+	)
+	if err != nil {
+		return fmt.Errorf("store: insert receipt: %w", err)
+	}
+
+	for i, item := range receipt.Items {
+		_, err := tx.ExecContext(ctx,
+			s.bind(`INSERT INTO receipt_items (receipt_id, position, short_description, price) VALUES (?, ?, ?, ?)`),
+			receipt.ID, i, item.ShortDescription, item.Price,
+		)
+		if err != nil {
+			return fmt.Errorf("store: insert item: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetReceipt looks up a receipt by ID, including its items.
+func (s *SQLStore) GetReceipt(ctx context.Context, id string) (models.Receipt, bool, error) {
+	row := s.db.QueryRowContext(ctx, s.bind(`SELECT id, retailer, purchase_date, purchase_time, total, points, created_at FROM receipts WHERE id = ?`), id)
+
+	var receipt models.Receipt
+	var createdAt time.Time
+	err := row.Scan(&receipt.ID, &receipt.Retailer, &receipt.PurchaseDate, &receipt.PurchaseTime, &receipt.Total, &receipt.Points, &createdAt)
+	if err == sql.ErrNoRows {
+		return models.Receipt{}, false, nil
+	}
+	if err != nil {
+		return models.Receipt{}, false, fmt.Errorf("store: select receipt: %w", err)
+	}
+	receipt.CreatedAt = createdAt.UTC().Format(time.RFC3339)
+
+	items, err := s.itemsFor(ctx, id)
+	if err != nil {
+		return models.Receipt{}, false, err
+	}
+	receipt.Items = items
+	return receipt, true, nil
+}
+
+func (s *SQLStore) itemsFor(ctx context.Context, receiptID string) ([]models.Item, error) {
+	rows, err := s.db.QueryContext(ctx, s.bind(`SELECT short_description, price FROM receipt_items WHERE receipt_id = ? ORDER BY position`), receiptID)
+	if err != nil {
+		return nil, fmt.Errorf("store: select items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []models.Item
+	for rows.Next() {
+		var item models.Item
+		if err := rows.Scan(&item.ShortDescription, &item.Price); err != nil {
+			return nil, fmt.Errorf("store: scan item: %w", err)
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// itemsForAll fetches the items for every receipt in receiptIDs in a single
+// query and groups them by receipt ID, preserving each receipt's item
+// order. Used by ListReceipts to avoid an N+1 query per page.
+func (s *SQLStore) itemsForAll(ctx context.Context, receiptIDs []string) (map[string][]models.Item, error) {
+	itemsByReceipt := make(map[string][]models.Item, len(receiptIDs))
+	if len(receiptIDs) == 0 {
+		return itemsByReceipt, nil
+	}
+
+	placeholders := make([]string, len(receiptIDs))
+	args := make([]interface{}, len(receiptIDs))
+	for i, id := range receiptIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	query := `SELECT receipt_id, short_description, price FROM receipt_items WHERE receipt_id IN (` +
+		strings.Join(placeholders, ", ") + `) ORDER BY receipt_id, position`
+	rows, err := s.db.QueryContext(ctx, s.bind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("store: select items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var receiptID string
+		var item models.Item
+		if err := rows.Scan(&receiptID, &item.ShortDescription, &item.Price); err != nil {
+			return nil, fmt.Errorf("store: scan item: %w", err)
+		}
+		itemsByReceipt[receiptID] = append(itemsByReceipt[receiptID], item)
+	}
+	return itemsByReceipt, rows.Err()
+}
+
+// ListReceipts returns the receipts matching filter, paged and ordered
+// according to paging, along with the total number of matching receipts.
+func (s *SQLStore) ListReceipts(ctx context.Context, filter models.ReceiptFilter, paging models.Paging) ([]models.Receipt, int, error) {
+	where, args := s.filterClause(filter)
+
+	var totalCount int
+	countQuery := "SELECT COUNT(*) FROM receipts" + where
+	if err := s.db.QueryRowContext(ctx, s.bind(countQuery), args...).Scan(&totalCount); err != nil {
+		return nil, 0, fmt.Errorf("store: count receipts: %w", err)
+	}
+
+	query := "SELECT id, retailer, purchase_date, purchase_time, total, points, created_at FROM receipts" + where +
+		" ORDER BY " + orderByColumn(paging.OrderBy) + " " + sortDirectionSQL(paging.SortDirection) +
+		" LIMIT ? OFFSET ?"
+	pageSize := paging.PageSize
+	offset := (paging.Page - 1) * pageSize
+	args = append(args, pageSize, offset)
+
+	rows, err := s.db.QueryContext(ctx, s.bind(query), args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("store: select receipts: %w", err)
+	}
+	defer rows.Close()
+
+	var receipts []models.Receipt
+	for rows.Next() {
+		var receipt models.Receipt
+		var createdAt time.Time
+		if err := rows.Scan(&receipt.ID, &receipt.Retailer, &receipt.PurchaseDate, &receipt.PurchaseTime, &receipt.Total, &receipt.Points, &createdAt); err != nil {
+			return nil, 0, fmt.Errorf("store: scan receipt: %w", err)
+		}
+		receipt.CreatedAt = createdAt.UTC().Format(time.RFC3339)
+		receipts = append(receipts, receipt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]string, len(receipts))
+	for i, receipt := range receipts {
+		ids[i] = receipt.ID
+	}
+	itemsByReceipt, err := s.itemsForAll(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i, receipt := range receipts {
+		receipts[i].Items = itemsByReceipt[receipt.ID]
+	}
+	return receipts, totalCount, nil
+}
+
+// filterClause builds a " WHERE ..." SQL fragment (or "" if filter is
+// empty) and the matching positional arguments for filter.
+func (s *SQLStore) filterClause(filter models.ReceiptFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Retailer != "" {
+		conditions = append(conditions, "retailer LIKE ?")
+		args = append(args, "%"+filter.Retailer+"%")
+	}
+	if filter.PurchaseDateFrom != "" {
+		conditions = append(conditions, "purchase_date >= ?")
+		args = append(args, filter.PurchaseDateFrom)
+	}
+	if filter.PurchaseDateTo != "" {
+		conditions = append(conditions, "purchase_date <= ?")
+		args = append(args, filter.PurchaseDateTo)
+	}
+	if filter.CreatedAtFrom != "" {
+		if t, err := time.Parse(time.RFC3339, filter.CreatedAtFrom); err == nil {
+			conditions = append(conditions, "created_at >= ?")
+			args = append(args, t)
+		}
+	}
+	if filter.CreatedAtTo != "" {
+		if t, err := time.Parse(time.RFC3339, filter.CreatedAtTo); err == nil {
+			conditions = append(conditions, "created_at <= ?")
+			args = append(args, t)
+		}
+	}
+	if filter.TotalMin != nil {
+		conditions = append(conditions, "CAST(total AS REAL) >= ?")
+		args = append(args, *filter.TotalMin)
+	}
+	if filter.TotalMax != nil {
+		conditions = append(conditions, "CAST(total AS REAL) <= ?")
+		args = append(args, *filter.TotalMax)
+	}
+	if filter.PointsMin != nil {
+		conditions = append(conditions, "points >= ?")
+		args = append(args, *filter.PointsMin)
+	}
+	if filter.PointsMax != nil {
+		conditions = append(conditions, "points <= ?")
+		args = append(args, *filter.PointsMax)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// orderByColumn maps an API-facing orderBy value to its column, defaulting
+// to purchase_date for anything unrecognized.
+func orderByColumn(orderBy string) string {
+	switch orderBy {
+	case "retailer":
+		return "retailer"
+	case "total":
+		return "CAST(total AS REAL)"
+	case "points":
+		return "points"
+	case "createdAt":
+		return "created_at"
+	default:
+		return "purchase_date"
+	}
+}
+
+func sortDirectionSQL(sortDirection string) string {
+	if sortDirection == "desc" {
+		return "DESC"
+	}
+	return "ASC"
+}
+
+// UpdateReceipt replaces the receipt (and its items) stored under id.
+func (s *SQLStore) UpdateReceipt(ctx context.Context, id string, receipt models.Receipt) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("store: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		s.bind(`UPDATE receipts SET retailer = ?, purchase_date = ?, purchase_time = ?, total = ?, points = ? WHERE id = ?`),
+		receipt.Retailer, receipt.PurchaseDate, receipt.PurchaseTime, receipt.Total, receipt.Points, id,
+	)
+	if err != nil {
+		return fmt.Errorf("store: update receipt: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("receipt %s not found", id)
+	}
+
+	if _, err := tx.ExecContext(ctx, s.bind(`DELETE FROM receipt_items WHERE receipt_id = ?`), id); err != nil {
+		return fmt.Errorf("store: clear items: %w", err)
+	}
+
+	receipt.ID = id
+	for i, item := range receipt.Items {
+		if _, err := tx.ExecContext(ctx,
+			s.bind(`INSERT INTO receipt_items (receipt_id, position, short_description, price) VALUES (?, ?, ?, ?)`),
+			id, i, item.ShortDescription, item.Price,
+		); err != nil {
+			return fmt.Errorf("store: insert item: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// DeleteReceipt removes the receipt (and its items) stored under id.
+func (s *SQLStore) DeleteReceipt(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, s.bind(`DELETE FROM receipts WHERE id = ?`), id)
+	if err != nil {
+		return fmt.Errorf("store: delete receipt: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("receipt %s not found", id)
+	}
+	return nil
+}